@@ -0,0 +1,79 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/Durudex/durudex-gateway/internal/auth/jwt"
+	"github.com/Durudex/durudex-gateway/internal/config"
+	"github.com/Durudex/durudex-gateway/internal/delivery/graphql"
+	grpcclients "github.com/Durudex/durudex-gateway/internal/delivery/grpc/client"
+	grpcserver "github.com/Durudex/durudex-gateway/internal/delivery/grpc/server"
+	"github.com/rs/zerolog/log"
+)
+
+func main() {
+	cfg, err := config.Init()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error initializing config")
+	}
+
+	var verifier atomic.Pointer[jwt.Verifier]
+	verifier.Store(jwt.NewVerifier(cfg.Auth.JWT.SigningKey))
+
+	// Rotate the JWT verifier whenever the config source reports a change,
+	// so that a signing key rotation propagates without a restart.
+	go func() {
+		for event := range config.Watch() {
+			log.Info().Msg("Config changed, rotating JWT verifier")
+			verifier.Store(jwt.NewVerifier(event.Config.Auth.JWT.SigningKey))
+		}
+	}()
+
+	// Dial the downstream services through grpcclient, so that the mTLS
+	// and per-RPC service credentials declared in config are enforced
+	// rather than merely declared.
+	clients, err := grpcclients.New(cfg.Service, cfg.Auth.Interservice)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error dialing downstream services")
+	}
+
+	// Start the gateway gRPC server in the background, so that internal
+	// services can validate access tokens centrally.
+	go func() {
+		addr := fmt.Sprintf("%s:%s", cfg.GRPC.Host, cfg.GRPC.Port)
+		if err := grpcserver.Serve(addr, verifier.Load, cfg.GRPC.TLS, cfg.Auth.Interservice); err != nil {
+			log.Fatal().Err(err).Msg("Error serving gateway gRPC server")
+		}
+	}()
+
+	// Wrap the GraphQL handler so every request's bearer token is parsed
+	// and verified before it reaches resolvers, making jwt.FromContext
+	// (and therefore the @hasScope directive) actually work.
+	handler := graphql.AuthMiddleware(verifier.Load)(graphql.NewHandler(cfg, clients))
+	addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
+
+	log.Info().Msgf("Starting GraphQL server on %s", addr)
+
+	if err := http.ListenAndServe(addr, handler); err != nil {
+		log.Fatal().Err(err).Msg("Error serving GraphQL server")
+	}
+}
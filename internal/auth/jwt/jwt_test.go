@@ -0,0 +1,114 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jwt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Durudex/durudex-gateway/internal/auth/scope"
+	gojwt "github.com/golang-jwt/jwt/v4"
+)
+
+const testSigningKey = "test-signing-key"
+
+func signClaims(t *testing.T, claims Claims, signingKey string) string {
+	t.Helper()
+
+	token := gojwt.NewWithClaims(gojwt.SigningMethodHS256, claims)
+
+	signed, err := token.SignedString([]byte(signingKey))
+	if err != nil {
+		t.Fatalf("signing token: %v", err)
+	}
+
+	return signed
+}
+
+func TestVerifierVerify(t *testing.T) {
+	claims := Claims{
+		RegisteredClaims: gojwt.RegisteredClaims{
+			Subject:   "user-1",
+			ExpiresAt: gojwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Scope: []scope.Scope{{Type: "user", Resource: "user-1", Role: "owner"}},
+	}
+	signed := signClaims(t, claims, testSigningKey)
+
+	verifier := NewVerifier(testSigningKey)
+
+	got, err := verifier.Verify(signed)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	if got.Subject != claims.Subject {
+		t.Errorf("Subject = %q, want %q", got.Subject, claims.Subject)
+	}
+	if len(got.Scope) != 1 || got.Scope[0] != claims.Scope[0] {
+		t.Errorf("Scope = %v, want %v", got.Scope, claims.Scope)
+	}
+}
+
+func TestVerifierVerifyRejectsWrongSigningKey(t *testing.T) {
+	claims := Claims{RegisteredClaims: gojwt.RegisteredClaims{Subject: "user-1"}}
+	signed := signClaims(t, claims, testSigningKey)
+
+	verifier := NewVerifier("a-different-key")
+
+	if _, err := verifier.Verify(signed); err == nil {
+		t.Error("Verify() error = nil, want error for a mismatched signing key")
+	}
+}
+
+func TestVerifierVerifyRejectsExpiredToken(t *testing.T) {
+	claims := Claims{
+		RegisteredClaims: gojwt.RegisteredClaims{
+			Subject:   "user-1",
+			ExpiresAt: gojwt.NewNumericDate(time.Now().Add(-time.Hour)),
+		},
+	}
+	signed := signClaims(t, claims, testSigningKey)
+
+	verifier := NewVerifier(testSigningKey)
+
+	if _, err := verifier.Verify(signed); err == nil {
+		t.Error("Verify() error = nil, want error for an expired token")
+	}
+}
+
+func TestContextRoundTrip(t *testing.T) {
+	claims := &Claims{RegisteredClaims: gojwt.RegisteredClaims{Subject: "user-1"}}
+
+	ctx := NewContext(context.Background(), claims)
+
+	got, ok := FromContext(ctx)
+	if !ok {
+		t.Fatal("FromContext() ok = false, want true")
+	}
+	if got != claims {
+		t.Errorf("FromContext() = %v, want %v", got, claims)
+	}
+}
+
+func TestFromContextMissing(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("FromContext() ok = true, want false for a context without claims")
+	}
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package scope implements a registry of access token scope types, so that
+// services can recognize new resource types without recompiling the gateway.
+package scope
+
+// Scope is a single scope claim embedded in an access token.
+type Scope struct {
+	Type     string `json:"type"`
+	Resource string `json:"resource"`
+	Role     string `json:"role"`
+}
+
+// Matcher reports whether a held scope satisfies a requested scope.
+type Matcher func(held, requested Scope) bool
+
+// Registered scope type matchers.
+var registry = make(map[string]Matcher)
+
+// Register a matcher function for a scope type.
+func Register(scopeType string, matcher Matcher) { registry[scopeType] = matcher }
+
+// Allowed reports whether any of the held scopes satisfies the requested one.
+func Allowed(held []Scope, requested Scope) bool {
+	matcher, ok := registry[requested.Type]
+	if !ok {
+		matcher = exactMatch
+	}
+
+	for _, s := range held {
+		if matcher(s, requested) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// exactMatch is the default matcher used for scope types without a
+// registered matcher: the type, resource and role must match exactly.
+func exactMatch(held, requested Scope) bool {
+	return held.Type == requested.Type && held.Resource == requested.Resource && held.Role == requested.Role
+}
+
+// Register the built-in resource types known to the gateway.
+func init() {
+	Register("user", exactMatch)
+	Register("post", exactMatch)
+	Register("publicshare", exactMatch)
+}
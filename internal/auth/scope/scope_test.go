@@ -0,0 +1,60 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package scope
+
+import "testing"
+
+func TestAllowedExactMatch(t *testing.T) {
+	held := []Scope{{Type: "post", Resource: "post-1", Role: "reader"}}
+
+	tests := []struct {
+		name      string
+		held      []Scope
+		requested Scope
+		want      bool
+	}{
+		{"matching scope", held, Scope{Type: "post", Resource: "post-1", Role: "reader"}, true},
+		{"wrong role", held, Scope{Type: "post", Resource: "post-1", Role: "owner"}, false},
+		{"wrong resource", held, Scope{Type: "post", Resource: "post-2", Role: "reader"}, false},
+		{"wrong type", held, Scope{Type: "user", Resource: "post-1", Role: "reader"}, false},
+		{"no held scopes", nil, Scope{Type: "post", Resource: "post-1", Role: "reader"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Allowed(tt.held, tt.requested); got != tt.want {
+				t.Errorf("Allowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowedRegisteredMatcher(t *testing.T) {
+	const scopeType = "widget"
+
+	Register(scopeType, func(held, requested Scope) bool {
+		return held.Type == requested.Type && held.Role == requested.Role
+	})
+
+	held := []Scope{{Type: scopeType, Resource: "any-resource", Role: "owner"}}
+	requested := Scope{Type: scopeType, Resource: "different-resource", Role: "owner"}
+
+	if !Allowed(held, requested) {
+		t.Error("Allowed() = false, want true for a matcher that ignores resource")
+	}
+}
@@ -19,16 +19,16 @@ package config
 
 import (
 	"os"
-	"path/filepath"
+	"time"
 
 	"github.com/rs/zerolog/log"
-	"github.com/spf13/viper"
 )
 
 type (
 	// Config variables.
 	Config struct {
 		Server  ServerConfig  // Server config variables.
+		GRPC    GRPCConfig    // Gateway gRPC server config variables.
 		Service ServiceConfig // Service config variables.
 		Auth    AuthConfig    // Auth config variables.
 	}
@@ -40,6 +40,13 @@ type (
 		Name string `mapstructure:"name"`
 	}
 
+	// Gateway gRPC server config variables.
+	GRPCConfig struct {
+		Host string    `mapstructure:"host"`
+		Port string    `mapstructure:"port"`
+		TLS  TLSConfig `mapstructure:"tls"`
+	}
+
 	// TLS config variables.
 	TLSConfig struct {
 		Enable bool   `mapstructure:"enable"`
@@ -50,12 +57,20 @@ type (
 
 	// Auth config variables.
 	AuthConfig struct {
-		JWT JWTConfig `mapstructure:"jwt"`
+		JWT          JWTConfig          `mapstructure:"jwt"`
+		Interservice InterserviceConfig `mapstructure:"interservice"`
 	}
 
 	// JWT config variables.
 	JWTConfig struct{ SigningKey string }
 
+	// Interservice config variables, used to authenticate gRPC dials between
+	// the gateway and downstream services.
+	InterserviceConfig struct {
+		SharedKey string
+		TokenTTL  time.Duration `mapstructure:"token-ttl"`
+	}
+
 	// Service base config.
 	Service struct {
 		Addr string    `mapstructure:"addr"`
@@ -71,68 +86,57 @@ type (
 	}
 )
 
+// Active config provider, set by Init.
+var provider ConfigProvider
+
 // Initialize config.
 func Init() (*Config, error) {
 	log.Debug().Msg("Initialize config...")
 
-	// Populate defaults config variables.
-	populateDefaults()
-
-	// Parsing config file.
-	if err := parseConfigFile(); err != nil {
+	var err error
+	provider, err = newProvider()
+	if err != nil {
 		return nil, err
 	}
 
-	var cfg Config
-	// Unmarshal config keys.
-	if err := unmarshal(&cfg); err != nil {
+	cfg, err := provider.Load()
+	if err != nil {
 		return nil, err
 	}
 
-	// Set env configurations.
-	setFromEnv(&cfg)
-
-	return &cfg, nil
-}
-
-// Parsing config file.
-func parseConfigFile() error {
-	// Get config path variable.
-	configPath := os.Getenv("CONFIG_PATH")
-
-	// Check is config path variable empty.
-	if configPath == "" {
-		configPath = defaultConfigPath
+	if err := Validate(cfg); err != nil {
+		return nil, err
 	}
 
-	log.Debug().Msgf("Parsing config file: %s", configPath)
-
-	// Split path to folder and file.
-	dir, file := filepath.Split(configPath)
-
-	viper.AddConfigPath(dir)
-	viper.SetConfigName(file)
-
-	// Read config file.
-	return viper.ReadInConfig()
+	return cfg, nil
 }
 
-// Unmarshal config keys.
-func unmarshal(cfg *Config) error {
-	log.Debug().Msg("Unmarshal config keys...")
-
-	// Unmarshal server keys.
-	if err := viper.UnmarshalKey("server", &cfg.Server); err != nil {
-		return err
+// Watch returns the channel of config change events emitted by the active
+// provider; cmd/gateway reads it to rotate the JWT verifier without a
+// restart. Downstream gRPC connections are not re-dialed on a change. It
+// returns nil if the active provider doesn't support hot-reload.
+func Watch() <-chan Event {
+	if provider == nil {
+		return nil
 	}
-	// Unmarshal service keys.
-	return viper.UnmarshalKey("service", &cfg.Service)
+
+	return provider.Watch()
 }
 
 // Seting environment variables from .env file.
+//
+// These are legacy variable names kept for backwards compatibility; they
+// only apply when actually set, so they never clear a value that a
+// provider already populated under its own naming convention (e.g. the
+// envProvider's AUTH_JWT_SIGNINGKEY).
 func setFromEnv(cfg *Config) {
 	log.Debug().Msg("Set from environment configurations...")
 
 	// Auth variables.
-	cfg.Auth.JWT.SigningKey = os.Getenv("JWT_SIGNING_KEY")
+	if key, ok := os.LookupEnv("JWT_SIGNING_KEY"); ok {
+		cfg.Auth.JWT.SigningKey = key
+	}
+	if key, ok := os.LookupEnv("INTERSERVICE_SHARED_KEY"); ok {
+		cfg.Auth.Interservice.SharedKey = key
+	}
 }
@@ -0,0 +1,63 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// ConfigProvider loads gateway config from a source (file, env, remote KV)
+// and optionally watches it for changes.
+type ConfigProvider interface {
+	// Load reads and returns the current configuration.
+	Load() (*Config, error)
+	// Watch returns a channel of config change events, or nil if this
+	// provider doesn't support hot-reload.
+	Watch() <-chan Event
+}
+
+// EventType identifies what changed in a config reload.
+type EventType string
+
+const (
+	// EventReload is emitted whenever the underlying config source changes.
+	EventReload EventType = "reload"
+)
+
+// Event is emitted on a provider's Watch channel whenever the underlying
+// config source changes.
+type Event struct {
+	Type   EventType
+	Config *Config
+}
+
+// newProvider selects a ConfigProvider based on the CONFIG_SOURCE
+// environment variable, defaulting to the file-backed provider.
+func newProvider() (ConfigProvider, error) {
+	switch source := os.Getenv("CONFIG_SOURCE"); source {
+	case "", "file":
+		return newFileProvider(), nil
+	case "env":
+		return newEnvProvider(), nil
+	case "consul":
+		return newConsulProvider()
+	default:
+		return nil, fmt.Errorf("unknown config source: %s", source)
+	}
+}
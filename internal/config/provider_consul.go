@@ -0,0 +1,118 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v2"
+)
+
+// defaultConsulConfigKey is used when CONSUL_CONFIG_KEY is unset.
+const defaultConsulConfigKey = "durudex-gateway/config"
+
+// consulProvider loads config from a Consul KV key and watches it for
+// changes using blocking queries.
+type consulProvider struct {
+	client *consulapi.Client
+	key    string
+	events chan Event
+}
+
+// newConsulProvider creates a new Consul-backed config provider.
+func newConsulProvider() (*consulProvider, error) {
+	client, err := consulapi.NewClient(consulapi.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	key := os.Getenv("CONSUL_CONFIG_KEY")
+	if key == "" {
+		key = defaultConsulConfigKey
+	}
+
+	return &consulProvider{client: client, key: key, events: make(chan Event, 1)}, nil
+}
+
+// Load fetches and unmarshals the config key, then starts watching it.
+func (p *consulProvider) Load() (*Config, error) {
+	log.Debug().Msgf("Loading config from consul key: %s", p.key)
+
+	// Populate defaults config variables.
+	populateDefaults()
+
+	pair, _, err := p.client.KV().Get(p.key, nil)
+	if err != nil {
+		return nil, err
+	}
+	if pair == nil {
+		return nil, fmt.Errorf("config key %q not found in consul", p.key)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(pair.Value, &cfg); err != nil {
+		return nil, err
+	}
+
+	// Set env configurations.
+	setFromEnv(&cfg)
+
+	go p.watch(pair.ModifyIndex)
+
+	return &cfg, nil
+}
+
+// Watch returns the channel of config change events.
+func (p *consulProvider) Watch() <-chan Event { return p.events }
+
+// watch long-polls the config key for changes via a Consul blocking query.
+func (p *consulProvider) watch(lastIndex uint64) {
+	for {
+		pair, meta, err := p.client.KV().Get(p.key, &consulapi.QueryOptions{WaitIndex: lastIndex})
+		if err != nil {
+			log.Error().Err(err).Msg("Error watching consul config")
+			time.Sleep(time.Second)
+
+			continue
+		}
+
+		if pair == nil || meta.LastIndex == lastIndex {
+			lastIndex = meta.LastIndex
+			continue
+		}
+		lastIndex = meta.LastIndex
+
+		var cfg Config
+		if err := yaml.Unmarshal(pair.Value, &cfg); err != nil {
+			log.Error().Err(err).Msg("Error decoding consul config")
+			continue
+		}
+		setFromEnv(&cfg)
+
+		if err := Validate(&cfg); err != nil {
+			log.Error().Err(err).Msg("Error validating consul config")
+			continue
+		}
+
+		p.events <- Event{Type: EventReload, Config: &cfg}
+	}
+}
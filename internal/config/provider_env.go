@@ -0,0 +1,108 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// envProvider loads config entirely from environment variables, deriving
+// each variable name from the structs' existing mapstructure tags. It
+// doesn't support hot-reload, since there's no file or KV key to watch.
+type envProvider struct{}
+
+// newEnvProvider creates a new pure-env config provider.
+func newEnvProvider() *envProvider { return &envProvider{} }
+
+// Load populates a Config entirely from environment variables.
+func (p *envProvider) Load() (*Config, error) {
+	log.Debug().Msg("Loading config from environment...")
+
+	// Populate defaults config variables.
+	populateDefaults()
+
+	var cfg Config
+	if err := loadEnv(reflect.ValueOf(&cfg).Elem(), ""); err != nil {
+		return nil, err
+	}
+
+	// Set env configurations.
+	setFromEnv(&cfg)
+
+	return &cfg, nil
+}
+
+// Watch returns nil, since pure-env config has no source to watch.
+func (p *envProvider) Watch() <-chan Event { return nil }
+
+// loadEnv recursively populates cfg's fields from environment variables,
+// deriving each variable name from the field's mapstructure tag (or its
+// name) joined with its parent prefix, e.g. SERVICE_AUTH_ADDR.
+func loadEnv(v reflect.Value, prefix string) error {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+
+		name := field.Tag.Get("mapstructure")
+		if name == "" {
+			name = field.Name
+		}
+
+		key := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+
+		switch {
+		case fv.Type() == reflect.TypeOf(time.Duration(0)):
+			raw, ok := os.LookupEnv(key)
+			if !ok {
+				continue
+			}
+
+			d, err := time.ParseDuration(raw)
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", key, err)
+			}
+
+			fv.Set(reflect.ValueOf(d))
+		case fv.Kind() == reflect.Struct:
+			if err := loadEnv(fv, key); err != nil {
+				return err
+			}
+		case fv.Kind() == reflect.String:
+			if raw, ok := os.LookupEnv(key); ok {
+				fv.SetString(raw)
+			}
+		case fv.Kind() == reflect.Bool:
+			if raw, ok := os.LookupEnv(key); ok {
+				fv.SetBool(raw == "true" || raw == "1")
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+// setFromEnv used to unconditionally overwrite these two fields with
+// os.Getenv, silently clearing whatever loadEnv had already populated under
+// its own mapstructure-derived names when the legacy var wasn't set.
+func TestSetFromEnvDoesNotClearUnsetLegacyVars(t *testing.T) {
+	t.Setenv("AUTH_JWT_SIGNINGKEY", "from-loadEnv")
+
+	var cfg Config
+	if err := loadEnv(reflect.ValueOf(&cfg).Elem(), ""); err != nil {
+		t.Fatalf("loadEnv() error = %v", err)
+	}
+
+	if cfg.Auth.JWT.SigningKey != "from-loadEnv" {
+		t.Fatalf("after loadEnv: SigningKey = %q, want %q", cfg.Auth.JWT.SigningKey, "from-loadEnv")
+	}
+
+	setFromEnv(&cfg)
+
+	if cfg.Auth.JWT.SigningKey != "from-loadEnv" {
+		t.Errorf("after setFromEnv: SigningKey = %q, want %q", cfg.Auth.JWT.SigningKey, "from-loadEnv")
+	}
+}
+
+func TestSetFromEnvAppliesLegacyVarsWhenSet(t *testing.T) {
+	t.Setenv("JWT_SIGNING_KEY", "from-legacy-var")
+	t.Setenv("INTERSERVICE_SHARED_KEY", "from-legacy-shared")
+
+	var cfg Config
+	setFromEnv(&cfg)
+
+	if cfg.Auth.JWT.SigningKey != "from-legacy-var" {
+		t.Errorf("SigningKey = %q, want %q", cfg.Auth.JWT.SigningKey, "from-legacy-var")
+	}
+	if cfg.Auth.Interservice.SharedKey != "from-legacy-shared" {
+		t.Errorf("SharedKey = %q, want %q", cfg.Auth.Interservice.SharedKey, "from-legacy-shared")
+	}
+}
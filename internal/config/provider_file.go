@@ -0,0 +1,120 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+// fileProvider loads config from a Viper-backed config file and watches
+// it for changes.
+type fileProvider struct{ events chan Event }
+
+// newFileProvider creates a new file-backed config provider.
+func newFileProvider() *fileProvider { return &fileProvider{events: make(chan Event, 1)} }
+
+// Load reads and unmarshals the config file, then starts watching it.
+func (p *fileProvider) Load() (*Config, error) {
+	// Populate defaults config variables.
+	populateDefaults()
+
+	// Parsing config file.
+	if err := parseConfigFile(); err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	// Unmarshal config keys.
+	if err := unmarshal(&cfg); err != nil {
+		return nil, err
+	}
+
+	// Set env configurations.
+	setFromEnv(&cfg)
+
+	viper.OnConfigChange(func(in fsnotify.Event) {
+		log.Info().Msgf("Config file changed: %s", in.Name)
+
+		var updated Config
+		if err := unmarshal(&updated); err != nil {
+			log.Error().Err(err).Msg("Error reloading config")
+			return
+		}
+		setFromEnv(&updated)
+
+		if err := Validate(&updated); err != nil {
+			log.Error().Err(err).Msg("Error reloading config")
+			return
+		}
+
+		p.events <- Event{Type: EventReload, Config: &updated}
+	})
+	viper.WatchConfig()
+
+	return &cfg, nil
+}
+
+// Watch returns the channel of config change events.
+func (p *fileProvider) Watch() <-chan Event { return p.events }
+
+// Parsing config file.
+func parseConfigFile() error {
+	// Get config path variable.
+	configPath := os.Getenv("CONFIG_PATH")
+
+	// Check is config path variable empty.
+	if configPath == "" {
+		configPath = defaultConfigPath
+	}
+
+	log.Debug().Msgf("Parsing config file: %s", configPath)
+
+	// Split path to folder and file.
+	dir, file := filepath.Split(configPath)
+
+	viper.AddConfigPath(dir)
+	viper.SetConfigName(file)
+
+	// Read config file.
+	return viper.ReadInConfig()
+}
+
+// Unmarshal config keys.
+func unmarshal(cfg *Config) error {
+	log.Debug().Msg("Unmarshal config keys...")
+
+	// Unmarshal server keys.
+	if err := viper.UnmarshalKey("server", &cfg.Server); err != nil {
+		return err
+	}
+	// Unmarshal gateway gRPC server keys.
+	if err := viper.UnmarshalKey("grpc", &cfg.GRPC); err != nil {
+		return err
+	}
+	// Unmarshal auth keys.
+	if err := viper.UnmarshalKey("auth", &cfg.Auth); err != nil {
+		return err
+	}
+	// Unmarshal service keys.
+	return viper.UnmarshalKey("service", &cfg.Service)
+}
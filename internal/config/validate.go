@@ -0,0 +1,42 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import "fmt"
+
+// Validate checks that required config variables are set, so that a
+// missing section fails loudly instead of silently yielding zero values.
+func Validate(cfg *Config) error {
+	if cfg.Service.Auth.Addr == "" {
+		return fmt.Errorf("service.auth.addr must be set")
+	}
+	if cfg.Service.Code.Addr == "" {
+		return fmt.Errorf("service.code.addr must be set")
+	}
+	if cfg.Service.User.Addr == "" {
+		return fmt.Errorf("service.user.addr must be set")
+	}
+	if cfg.Service.Post.Addr == "" {
+		return fmt.Errorf("service.post.addr must be set")
+	}
+	if cfg.Auth.JWT.SigningKey == "" {
+		return fmt.Errorf("auth.jwt signing key must be set")
+	}
+
+	return nil
+}
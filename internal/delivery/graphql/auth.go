@@ -20,9 +20,12 @@ package graphql
 import (
 	"context"
 
+	gqlerrors "github.com/Durudex/durudex-gateway/internal/delivery/graphql/errors"
 	"github.com/Durudex/durudex-gateway/internal/delivery/graphql/generated"
 	"github.com/Durudex/durudex-gateway/internal/delivery/graphql/model"
 	pb "github.com/Durudex/durudex-gateway/internal/delivery/grpc/protobuf"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -42,9 +45,10 @@ func (r *mutationResolver) SignUp(ctx context.Context, input model.SignUpInput)
 		Birthday: timestamppb.New(input.Birthday),
 		Sex:      input.Sex,
 	}
-	id, err := r.service.Auth.SignUp(ctx, &user)
+	var trailer metadata.MD
+	id, err := r.service.Auth.SignUp(ctx, &user, grpc.Trailer(&trailer))
 	if err != nil {
-		return &model.SignUp{}, err
+		return &model.SignUp{}, gqlerrors.MapError(err, trailer)
 	}
 
 	return &model.SignUp{ID: id}, nil
@@ -57,9 +61,10 @@ func (r *mutationResolver) SignIn(ctx context.Context, input model.SignInInput)
 		Username: input.Username,
 		Password: input.Password,
 	}
-	tokens, err := r.service.Auth.SignIn(ctx, &user)
+	var trailer metadata.MD
+	tokens, err := r.service.Auth.SignIn(ctx, &user, grpc.Trailer(&trailer))
 	if err != nil {
-		return &model.SignIn{}, err
+		return &model.SignIn{}, gqlerrors.MapError(err, trailer)
 	}
 
 	return &model.SignIn{
@@ -74,9 +79,10 @@ func (r *mutationResolver) RefreshTokens(ctx context.Context, input model.Refres
 	refreshToken := pb.RefreshTokensRequest{
 		RefreshToken: input.RefreshToken,
 	}
-	tokens, err := r.service.Auth.RefreshTokens(ctx, &refreshToken)
+	var trailer metadata.MD
+	tokens, err := r.service.Auth.RefreshTokens(ctx, &refreshToken, grpc.Trailer(&trailer))
 	if err != nil {
-		return &model.RefreshTokens{}, err
+		return &model.RefreshTokens{}, gqlerrors.MapError(err, trailer)
 	}
 
 	return &model.RefreshTokens{
@@ -97,9 +103,10 @@ func (r *mutationResolver) GetCode(ctx context.Context, input model.GetCodeInput
 		Name:  input.Name,
 	}
 
-	status, err := r.service.Auth.GetCode(ctx, &request)
+	var trailer metadata.MD
+	status, err := r.service.Auth.GetCode(ctx, &request, grpc.Trailer(&trailer))
 	if err != nil {
-		return &model.Status{Status: false}, err
+		return &model.Status{Status: false}, gqlerrors.MapError(err, trailer)
 	}
 
 	return &model.Status{Status: status}, nil
@@ -3,15 +3,21 @@ package graphql
 import (
 	"context"
 
+	"github.com/Durudex/durudex-gateway/internal/auth/jwt"
+	"github.com/Durudex/durudex-gateway/internal/auth/scope"
+	gqlerrors "github.com/Durudex/durudex-gateway/internal/delivery/graphql/errors"
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/vektah/gqlparser/v2/gqlerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 // GraphQL directive for checking email code.
 func (h *Handler) emailCode(ctx context.Context, obj interface{}, next graphql.Resolver, email string, code uint64) (interface{}, error) {
-	status, err := h.service.Code.CheckByEmail(ctx, email, code)
+	var trailer metadata.MD
+	status, err := h.service.Code.CheckByEmail(ctx, email, code, grpc.Trailer(&trailer))
 	if err != nil {
-		return nil, err
+		return nil, gqlerrors.MapError(err, trailer)
 	}
 
 	// Check codes.
@@ -21,3 +27,41 @@ func (h *Handler) emailCode(ctx context.Context, obj interface{}, next graphql.R
 
 	return next(ctx)
 }
+
+// GraphQL directive for checking a scope on the request access token.
+//
+// resourceArg names the field argument that carries the resource to check
+// the scope against (e.g. "id" for `deletePost(id: ID!)`), so a field
+// whose identifying argument isn't literally called "id" still authorizes
+// against the right resource instead of silently falling back to an empty
+// one. Declared in the schema as:
+//
+//	directive @hasScope(type: String!, role: String!, resourceArg: String = "id") on FIELD_DEFINITION
+func (h *Handler) hasScope(ctx context.Context, obj interface{}, next graphql.Resolver, typ string, role string, resourceArg string) (interface{}, error) {
+	claims, ok := jwt.FromContext(ctx)
+	if !ok {
+		return nil, &gqlerror.Error{
+			Message:    "Unauthenticated",
+			Extensions: map[string]interface{}{"code": "UNAUTHORIZED"},
+		}
+	}
+
+	// Resolve the requested resource from the field argument named by
+	// resourceArg, if any.
+	var resource string
+	if fc := graphql.GetFieldContext(ctx); fc != nil {
+		if id, ok := fc.Args[resourceArg].(string); ok {
+			resource = id
+		}
+	}
+
+	requested := scope.Scope{Type: typ, Resource: resource, Role: role}
+	if !scope.Allowed(claims.Scope, requested) {
+		return nil, &gqlerror.Error{
+			Message:    "Forbidden",
+			Extensions: map[string]interface{}{"code": "FORBIDDEN"},
+		}
+	}
+
+	return next(ctx)
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graphql
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	gqlerrors "github.com/Durudex/durudex-gateway/internal/delivery/graphql/errors"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ErrorPresenter maps resolver errors into the structured GraphQL error
+// taxonomy. Wire it up with handler.Server.SetErrorPresenter so that every
+// resolver error, not just the ones mapped explicitly, goes through it.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	if gqlErr, ok := err.(*gqlerror.Error); ok {
+		return gqlErr
+	}
+
+	mapped := gqlerrors.MapError(err, nil)
+	mapped.Path = graphql.GetPath(ctx)
+
+	return mapped
+}
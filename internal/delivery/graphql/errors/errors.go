@@ -0,0 +1,89 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package errors maps downstream gRPC errors to a structured GraphQL error
+// taxonomy, so resolvers don't leak raw gRPC status codes or internal
+// messages to clients.
+package errors
+
+import (
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC code to GraphQL error code mapping.
+var codeMapping = map[codes.Code]string{
+	codes.Unauthenticated:  "UNAUTHORIZED",
+	codes.PermissionDenied: "FORBIDDEN",
+	codes.InvalidArgument:  "BAD_USER_INPUT",
+	codes.NotFound:         "NOT_FOUND",
+	codes.AlreadyExists:    "CONFLICT",
+}
+
+// MapError translates a downstream error into a structured GraphQL error,
+// storing the GraphQL error code mapped from its gRPC status code as the
+// "code" extension, and using INTERNAL with a redacted message for
+// non-gRPC errors and unmapped codes. trailer is the gRPC trailer
+// metadata captured from the downstream call that produced err (via the
+// grpc.Trailer call option), used to recover the request-id the
+// downstream service attached to its response, so a user can correlate
+// this GraphQL error with upstream service logs.
+func MapError(err error, trailer metadata.MD) *gqlerror.Error {
+	if err == nil {
+		return nil
+	}
+
+	gqlErr := &gqlerror.Error{Extensions: map[string]interface{}{}}
+
+	if reqID := requestID(trailer); reqID != "" {
+		gqlErr.Extensions["requestId"] = reqID
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		gqlErr.Message = "Internal server error"
+		gqlErr.Extensions["code"] = "INTERNAL"
+
+		return gqlErr
+	}
+
+	code, ok := codeMapping[st.Code()]
+	if !ok {
+		gqlErr.Message = "Internal server error"
+		gqlErr.Extensions["code"] = "INTERNAL"
+
+		return gqlErr
+	}
+
+	gqlErr.Message = st.Message()
+	gqlErr.Extensions["code"] = code
+
+	return gqlErr
+}
+
+// requestID returns the "request-id" value from trailer, the metadata a
+// downstream service attaches to its gRPC response, if any.
+func requestID(trailer metadata.MD) string {
+	ids := trailer.Get("request-id")
+	if len(ids) == 0 {
+		return ""
+	}
+
+	return ids[0]
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestMapErrorNil(t *testing.T) {
+	if got := MapError(nil, nil); got != nil {
+		t.Errorf("MapError(nil) = %v, want nil", got)
+	}
+}
+
+func TestMapErrorCodeMapping(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode string
+	}{
+		{"unauthenticated", status.Error(codes.Unauthenticated, "bad token"), "UNAUTHORIZED"},
+		{"permission denied", status.Error(codes.PermissionDenied, "no scope"), "FORBIDDEN"},
+		{"invalid argument", status.Error(codes.InvalidArgument, "bad input"), "BAD_USER_INPUT"},
+		{"not found", status.Error(codes.NotFound, "missing"), "NOT_FOUND"},
+		{"already exists", status.Error(codes.AlreadyExists, "dup"), "CONFLICT"},
+		{"unmapped code", status.Error(codes.Internal, "boom"), "INTERNAL"},
+		{"non-grpc error", errors.New("plain error"), "INTERNAL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MapError(tt.err, nil)
+
+			code, _ := got.Extensions["code"].(string)
+			if code != tt.wantCode {
+				t.Errorf("code = %q, want %q", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestMapErrorRedactsInternalMessage(t *testing.T) {
+	got := MapError(status.Error(codes.Internal, "leaked stack trace"), nil)
+
+	if got.Message == "leaked stack trace" {
+		t.Error("MapError() leaked the internal gRPC error message")
+	}
+}
+
+// The request-id MapError surfaces comes back from the downstream gRPC
+// call as response trailer metadata (captured by the caller via the
+// grpc.Trailer call option), not from incoming metadata on the resolver's
+// context — gqlgen resolvers never carry gRPC incoming metadata, and even
+// if they did, it would be the wrong direction.
+func TestMapErrorIncludesRequestIDFromTrailer(t *testing.T) {
+	trailer := metadata.New(map[string]string{"request-id": "req-123"})
+
+	got := MapError(status.Error(codes.NotFound, "missing"), trailer)
+
+	if reqID, _ := got.Extensions["requestId"].(string); reqID != "req-123" {
+		t.Errorf("requestId = %q, want %q", reqID, "req-123")
+	}
+}
+
+func TestMapErrorOmitsRequestIDWhenAbsent(t *testing.T) {
+	got := MapError(status.Error(codes.NotFound, "missing"), nil)
+
+	if _, ok := got.Extensions["requestId"]; ok {
+		t.Error("Extensions[\"requestId\"] present, want absent without trailer metadata")
+	}
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graphql
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Durudex/durudex-gateway/internal/auth/jwt"
+)
+
+// AuthMiddleware parses the bearer access token from each request's
+// Authorization header, verifies it with the currently active verifier, and
+// stores its claims in the request context so that the @hasScope directive
+// can authorize the request once it reaches the resolver chain. A missing
+// or invalid token isn't rejected here: fields without @hasScope stay
+// public, and FromContext simply reports ok == false downstream.
+func AuthMiddleware(verifier func() *jwt.Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token, ok := bearerToken(r); ok {
+				if claims, err := verifier().Verify(token); err == nil {
+					r = r.WithContext(jwt.NewContext(r.Context(), claims))
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// bearerToken extracts the access token from a "Bearer <token>"
+// Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, prefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(header, prefix), true
+}
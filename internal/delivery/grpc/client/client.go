@@ -0,0 +1,71 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package client constructs the gateway's connections to its downstream
+// services, dialing every one of them through grpcclient so that the mTLS
+// and per-RPC service credentials declared in config are actually enforced
+// rather than merely available.
+package client
+
+import (
+	"fmt"
+
+	"github.com/Durudex/durudex-gateway/internal/config"
+	pb "github.com/Durudex/durudex-gateway/internal/delivery/grpc/protobuf"
+	"github.com/Durudex/durudex-gateway/internal/transport/grpcclient"
+	"google.golang.org/grpc"
+)
+
+// Clients holds client connections to the downstream services the gateway
+// depends on.
+type Clients struct {
+	Auth pb.AuthServiceClient
+
+	Code *grpc.ClientConn
+	User *grpc.ClientConn
+	Post *grpc.ClientConn
+}
+
+// New dials the auth, code, user and post services through grpcclient.Dial.
+func New(cfg config.ServiceConfig, interservice config.InterserviceConfig) (*Clients, error) {
+	authConn, err := grpcclient.Dial("auth", cfg.Auth, interservice)
+	if err != nil {
+		return nil, fmt.Errorf("failed dialing auth service: %w", err)
+	}
+
+	codeConn, err := grpcclient.Dial("code", cfg.Code, interservice)
+	if err != nil {
+		return nil, fmt.Errorf("failed dialing code service: %w", err)
+	}
+
+	userConn, err := grpcclient.Dial("user", cfg.User, interservice)
+	if err != nil {
+		return nil, fmt.Errorf("failed dialing user service: %w", err)
+	}
+
+	postConn, err := grpcclient.Dial("post", cfg.Post, interservice)
+	if err != nil {
+		return nil, fmt.Errorf("failed dialing post service: %w", err)
+	}
+
+	return &Clients{
+		Auth: pb.NewAuthServiceClient(authConn),
+		Code: codeConn,
+		User: userConn,
+		Post: postConn,
+	}, nil
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package server implements the gateway's own gRPC AuthService, so that
+// internal services can validate access tokens centrally instead of
+// re-implementing JWT parsing.
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/Durudex/durudex-gateway/internal/auth/jwt"
+	"github.com/Durudex/durudex-gateway/internal/auth/scope"
+	"github.com/Durudex/durudex-gateway/internal/config"
+	pb "github.com/Durudex/durudex-gateway/internal/delivery/grpc/protobuf"
+	"github.com/Durudex/durudex-gateway/internal/transport/grpcclient"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// AuthServer implements the gateway.auth.GatewayAuthService gRPC service.
+type AuthServer struct {
+	pb.UnimplementedGatewayAuthServiceServer
+
+	// verifier returns the currently active verifier, so that a signing
+	// key rotation propagates without restarting the gRPC listener.
+	verifier func() *jwt.Verifier
+}
+
+// NewAuthServer creates a new gateway auth gRPC server.
+func NewAuthServer(verifier func() *jwt.Verifier) *AuthServer {
+	return &AuthServer{verifier: verifier}
+}
+
+// Verify parses and validates an access token.
+func (s *AuthServer) Verify(ctx context.Context, input *pb.VerifyRequest) (*pb.VerifyResponse, error) {
+	claims, err := s.verifier().Verify(input.GetToken())
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid access token")
+	}
+
+	scopes := make([]*pb.ScopeClaim, 0, len(claims.Scope))
+	for _, sc := range claims.Scope {
+		scopes = append(scopes, &pb.ScopeClaim{Type: sc.Type, Resource: sc.Resource, Role: sc.Role})
+	}
+
+	var expires int64
+	if claims.ExpiresAt != nil {
+		expires = claims.ExpiresAt.Unix()
+	}
+
+	return &pb.VerifyResponse{Subject: claims.Subject, Scope: scopes, Expires: expires}, nil
+}
+
+// Authorize checks whether an access token grants a verb on a resource.
+func (s *AuthServer) Authorize(ctx context.Context, input *pb.AuthorizeRequest) (*pb.AuthorizeResponse, error) {
+	claims, err := s.verifier().Verify(input.GetToken())
+	if err != nil {
+		return &pb.AuthorizeResponse{Allowed: false, Reason: "invalid access token"}, nil
+	}
+
+	held := make([]scope.Scope, 0, len(claims.Scope))
+	for _, sc := range claims.Scope {
+		held = append(held, sc)
+	}
+
+	requested := scope.Scope{Type: input.GetType(), Resource: input.GetResource(), Role: input.GetVerb()}
+	if !scope.Allowed(held, requested) {
+		return &pb.AuthorizeResponse{Allowed: false, Reason: "scope does not grant this verb on the resource"}, nil
+	}
+
+	return &pb.AuthorizeResponse{Allowed: true}, nil
+}
+
+// Serve brings up the gateway gRPC listener, registering the
+// GatewayAuthService.
+// When tlsCfg.Enable is set, the listener requires and verifies client
+// certificates signed by tlsCfg.CACert (mutual TLS). Every RPC also
+// requires a valid, unexpired interservice credential minted by a caller
+// that dialed through grpcclient.
+func Serve(addr string, verifier func() *jwt.Verifier, tlsCfg config.TLSConfig, interservice config.InterserviceConfig) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(grpcclient.UnaryServerInterceptor(interservice))}
+	if tlsCfg.Enable {
+		creds, err := serverTLSCredentials(tlsCfg)
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	srv := grpc.NewServer(opts...)
+	pb.RegisterGatewayAuthServiceServer(srv, NewAuthServer(verifier))
+
+	log.Info().Msgf("Starting gateway gRPC server on %s", addr)
+
+	return srv.Serve(lis)
+}
+
+// serverTLSCredentials builds mTLS server credentials from a TLS config,
+// requiring and verifying client certificates against the configured CA.
+func serverTLSCredentials(cfg config.TLSConfig) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.Cert, cfg.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading gateway gRPC server certificate: %w", err)
+	}
+
+	caCert, err := os.ReadFile(cfg.CACert)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading gateway gRPC CA certificate: %w", err)
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed parsing gateway gRPC CA certificate")
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}), nil
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpcclient
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Durudex/durudex-gateway/internal/config"
+)
+
+// perRPCCredentials attaches a short-lived, HMAC-signed service-to-service
+// token to every RPC, in the form "service|timestamp|nonce|signature".
+type perRPCCredentials struct {
+	service      string
+	interservice config.InterserviceConfig
+	secure       bool
+}
+
+// newPerRPCCredentials creates per-RPC credentials for dialing a service
+// named name, signed with the shared interservice key.
+func newPerRPCCredentials(name string, interservice config.InterserviceConfig, secure bool) *perRPCCredentials {
+	return &perRPCCredentials{service: name, interservice: interservice, secure: secure}
+}
+
+// GetRequestMetadata mints a fresh service-to-service token for this RPC.
+func (c *perRPCCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := c.sign(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"authorization": "Service " + token}, nil
+}
+
+// RequireTransportSecurity reports whether the credentials require a
+// secure channel, mirroring whether TLS is enabled for this dial.
+func (c *perRPCCredentials) RequireTransportSecurity() bool { return c.secure }
+
+// sign builds a "service|timestamp|nonce|signature" token, where signature
+// is the HMAC-SHA256 of "service|timestamp|nonce" under the shared key.
+func (c *perRPCCredentials) sign(now time.Time) (string, error) {
+	nonce := make([]byte, 12)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	payload := fmt.Sprintf("%s|%d|%s", c.service, now.Unix(), hex.EncodeToString(nonce))
+
+	mac := hmac.New(sha256.New, []byte(c.interservice.SharedKey))
+	mac.Write([]byte(payload))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	return payload + "|" + signature, nil
+}
+
+// ttl returns the configured token lifetime, falling back to a sane
+// default when unset.
+func (c *perRPCCredentials) ttl() time.Duration {
+	if c.interservice.TokenTTL <= 0 {
+		return defaultTokenTTL
+	}
+
+	return c.interservice.TokenTTL
+}
+
+// defaultTokenTTL is used when InterserviceConfig.TokenTTL is unset.
+const defaultTokenTTL = 30 * time.Second
+
+// verify checks a "service|timestamp|nonce|signature" token against the
+// shared key and rejects it if it is older than ttl(), so that a leaked or
+// replayed token only works for a bounded window.
+func (c *perRPCCredentials) verify(token string, now time.Time) error {
+	parts := strings.SplitN(token, "|", 4)
+	if len(parts) != 4 {
+		return fmt.Errorf("malformed service token")
+	}
+
+	service, rawTimestamp, nonce, signature := parts[0], parts[1], parts[2], parts[3]
+
+	mac := hmac.New(sha256.New, []byte(c.interservice.SharedKey))
+	mac.Write([]byte(fmt.Sprintf("%s|%s|%s", service, rawTimestamp, nonce)))
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("invalid service token signature")
+	}
+
+	issued, err := strconv.ParseInt(rawTimestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid service token timestamp: %w", err)
+	}
+
+	if now.Sub(time.Unix(issued, 0)) > c.ttl() {
+		return fmt.Errorf("expired service token")
+	}
+
+	return nil
+}
+
+// VerifyToken validates a token minted by sign for a dial under
+// interservice, given without its "Service " authorization prefix, so that a gRPC server can authenticate callers
+// that dial through this package, rejecting a bad signature or a token
+// older than interservice.TokenTTL.
+func VerifyToken(token string, interservice config.InterserviceConfig) error {
+	return (&perRPCCredentials{interservice: interservice}).verify(token, time.Now())
+}
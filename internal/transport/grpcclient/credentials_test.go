@@ -0,0 +1,114 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpcclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Durudex/durudex-gateway/internal/config"
+)
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	creds := newPerRPCCredentials("auth", config.InterserviceConfig{SharedKey: "shared-secret"}, true)
+
+	token, err := creds.sign(time.Now())
+	if err != nil {
+		t.Fatalf("sign() error = %v", err)
+	}
+
+	if err := creds.verify(token, time.Now()); err != nil {
+		t.Errorf("verify() error = %v, want nil for a freshly signed token", err)
+	}
+}
+
+func TestVerifyRejectsTamperedSignature(t *testing.T) {
+	creds := newPerRPCCredentials("auth", config.InterserviceConfig{SharedKey: "shared-secret"}, true)
+
+	token, err := creds.sign(time.Now())
+	if err != nil {
+		t.Fatalf("sign() error = %v", err)
+	}
+
+	tampered := token[:len(token)-1] + "0"
+	if tampered == token {
+		tampered = token[:len(token)-1] + "1"
+	}
+
+	if err := creds.verify(tampered, time.Now()); err == nil {
+		t.Error("verify() error = nil, want error for a tampered signature")
+	}
+}
+
+func TestVerifyRejectsWrongSharedKey(t *testing.T) {
+	signer := newPerRPCCredentials("auth", config.InterserviceConfig{SharedKey: "shared-secret"}, true)
+	verifier := newPerRPCCredentials("auth", config.InterserviceConfig{SharedKey: "a-different-secret"}, true)
+
+	token, err := signer.sign(time.Now())
+	if err != nil {
+		t.Fatalf("sign() error = %v", err)
+	}
+
+	if err := verifier.verify(token, time.Now()); err == nil {
+		t.Error("verify() error = nil, want error for a token signed under a different shared key")
+	}
+}
+
+func TestVerifyRejectsMalformedToken(t *testing.T) {
+	creds := newPerRPCCredentials("auth", config.InterserviceConfig{SharedKey: "shared-secret"}, true)
+
+	if err := creds.verify("not-a-valid-token", time.Now()); err == nil {
+		t.Error("verify() error = nil, want error for a malformed token")
+	}
+}
+
+func TestTTLFallsBackToDefaultWhenUnset(t *testing.T) {
+	creds := newPerRPCCredentials("auth", config.InterserviceConfig{SharedKey: "shared-secret"}, true)
+
+	if got := creds.ttl(); got != defaultTokenTTL {
+		t.Errorf("ttl() = %v, want %v", got, defaultTokenTTL)
+	}
+}
+
+func TestTTLUsesConfiguredValue(t *testing.T) {
+	const want = 5 * time.Second
+
+	creds := newPerRPCCredentials("auth", config.InterserviceConfig{SharedKey: "shared-secret", TokenTTL: want}, true)
+
+	if got := creds.ttl(); got != want {
+		t.Errorf("ttl() = %v, want %v", got, want)
+	}
+}
+
+func TestVerifyBoundsWindowByConfiguredTTL(t *testing.T) {
+	creds := newPerRPCCredentials("auth", config.InterserviceConfig{SharedKey: "shared-secret", TokenTTL: time.Minute}, true)
+
+	issued := time.Now()
+
+	token, err := creds.sign(issued)
+	if err != nil {
+		t.Fatalf("sign() error = %v", err)
+	}
+
+	if err := creds.verify(token, issued.Add(30*time.Second)); err != nil {
+		t.Errorf("verify() error = %v, want nil within TokenTTL", err)
+	}
+	if err := creds.verify(token, issued.Add(90*time.Second)); err == nil {
+		t.Error("verify() error = nil, want error for a token older than TokenTTL")
+	}
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright © 2021-2022 Durudex
+
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpcclient
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Durudex/durudex-gateway/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor authenticates incoming RPCs using the
+// "authorization: Service <token>" per-RPC credential attached by Dial,
+// rejecting callers that don't present a valid, unexpired token. Use this
+// on a gRPC server that internal services dial through this package.
+func UnaryServerInterceptor(interservice config.InterserviceConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "missing service credentials")
+		}
+
+		values := md.Get("authorization")
+		if len(values) == 0 || !strings.HasPrefix(values[0], "Service ") {
+			return nil, status.Error(codes.Unauthenticated, "missing service credentials")
+		}
+
+		token := strings.TrimPrefix(values[0], "Service ")
+		if err := VerifyToken(token, interservice); err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid service credentials")
+		}
+
+		return handler(ctx, req)
+	}
+}